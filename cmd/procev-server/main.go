@@ -0,0 +1,46 @@
+// Command procev-server exposes process events from the local host's proc
+// connector over gRPC.
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+
+	"google.golang.org/grpc"
+
+	"github.com/vsurjaninov/procev/pmon"
+	procevgrpc "github.com/vsurjaninov/procev/pmon/grpc"
+	"github.com/vsurjaninov/procev/pmon/grpc/procevpb"
+)
+
+func main() {
+	addr := flag.String("listen", ":50051", "address to listen on")
+	flag.Parse()
+
+	listener := &pmon.ProcListener{}
+	if err := listener.Connect(); err != nil {
+		log.Fatalf("procev-server: connect: %v", err)
+	}
+	defer listener.Close()
+
+	go listener.ListenEvents()
+	go func() {
+		for err := range listener.Error {
+			log.Printf("procev-server: listener error: %v", err)
+		}
+	}()
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("procev-server: listen: %v", err)
+	}
+
+	grpcServer := grpc.NewServer()
+	procevpb.RegisterProcEventsServer(grpcServer, procevgrpc.NewServer(listener))
+
+	log.Printf("procev-server: listening on %s", *addr)
+	if err := grpcServer.Serve(lis); err != nil {
+		log.Fatalf("procev-server: serve: %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+// Command procev runs a ProcListener and fans events out into whichever
+// sinks are enabled on the command line.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/vsurjaninov/procev/pmon"
+	"github.com/vsurjaninov/procev/pmon/sink/jsonlsink"
+	"github.com/vsurjaninov/procev/pmon/sink/otelsink"
+	"github.com/vsurjaninov/procev/pmon/sink/promsink"
+)
+
+func main() {
+	jsonlPath := flag.String("jsonl", "", "path to append JSON-lines events to (- for stdout)")
+	promAddr := flag.String("prom-listen", "", "address to serve Prometheus metrics on, e.g. :9321 (disabled if empty)")
+	otelEndpoint := flag.String("otel-endpoint", "", "OTLP/HTTP logs endpoint to export events to, e.g. localhost:4318 (disabled if empty)")
+	otelInsecure := flag.Bool("otel-insecure", false, "use plaintext HTTP instead of TLS for -otel-endpoint")
+	sinkBuffer := flag.Int("sink-buffer", 256, "per-sink event buffer size")
+	flag.Parse()
+
+	listener := &pmon.ProcListener{}
+	if err := listener.Connect(); err != nil {
+		log.Fatalf("procev: connect: %v", err)
+	}
+	defer listener.Close()
+
+	if *jsonlPath != "" {
+		w := os.Stdout
+		if *jsonlPath != "-" {
+			f, err := os.OpenFile(*jsonlPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+			if err != nil {
+				log.Fatalf("procev: open jsonl sink: %v", err)
+			}
+			defer f.Close()
+			w = f
+		}
+		listener.RegisterSink(jsonlsink.New(w), *sinkBuffer)
+	}
+
+	if *promAddr != "" {
+		sink := promsink.New()
+		listener.RegisterSink(sink, *sinkBuffer)
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", sink.Handler())
+		go func() {
+			if err := http.ListenAndServe(*promAddr, mux); err != nil {
+				log.Fatalf("procev: prometheus listener: %v", err)
+			}
+		}()
+	}
+	if *otelEndpoint != "" {
+		opts := []otlploghttp.Option{otlploghttp.WithEndpoint(*otelEndpoint)}
+		if *otelInsecure {
+			opts = append(opts, otlploghttp.WithInsecure())
+		}
+		exporter, err := otlploghttp.New(context.Background(), opts...)
+		if err != nil {
+			log.Fatalf("procev: otel exporter: %v", err)
+		}
+
+		provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)))
+		defer provider.Shutdown(context.Background())
+
+		listener.RegisterSink(otelsink.New(provider.Logger("procev")), *sinkBuffer)
+	}
+	defer listener.CloseSinks()
+
+	go func() {
+		for err := range listener.Error {
+			log.Printf("procev: listener error: %v", err)
+		}
+	}()
+
+	log.Printf("procev: listening for process events")
+	listener.ListenEvents()
+}
@@ -0,0 +1,208 @@
+package pmon
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ProcInfo is a point-in-time snapshot of a process, as recorded by the
+// enrichment layer's ancestry tree.
+type ProcInfo struct {
+	Pid       uint32
+	ParentPid uint32
+	Comm      string
+}
+
+// EnrichedEventExec embeds a raw EventExec plus process metadata gathered
+// from /proc at the time the exec was observed.
+type EnrichedEventExec struct {
+	EventExec
+
+	Comm      string
+	Argv      []string
+	ExePath   string
+	ParentPid uint32
+	Ancestors []uint32
+}
+
+// enrichCacheSize bounds the /proc/<pid>/comm LRU cache so a burst of
+// short-lived execs cannot grow it unbounded.
+const enrichCacheSize = 4096
+
+// Enricher maintains an in-memory process tree built from EventFork, and
+// uses it plus /proc to annotate EventExec with comm, argv, exe path, and
+// ancestry. It is opt-in: callers that want enrichment call NewEnricher and
+// read EnrichedExec instead of the listener's raw EventExec channel.
+//
+// Enricher consumes events through a Subscription rather than the
+// listener's raw per-type channels. The raw channels are only offered via
+// a non-blocking send, so a consumer that isn't already scheduled and
+// ready to receive silently misses events; a Subscription's buffered
+// channels give Enricher's own goroutine room to fall behind briefly
+// (e.g. while it's blocked on enrichExec's /proc reads) without losing
+// forks it needs to track ancestry correctly.
+type Enricher struct {
+	sub *Subscription
+
+	EnrichedExec chan *EnrichedEventExec
+
+	mu      sync.Mutex
+	parents map[uint32]uint32
+	comms   map[uint32]string
+	lru     []uint32
+}
+
+// NewEnricher starts enriching events from listener. Callers must drain
+// EnrichedExec; Close stops enrichment and closes the channel.
+func NewEnricher(listener *ProcListener) *Enricher {
+	en := &Enricher{
+		sub:          listener.Subscribe(Filter{}),
+		EnrichedExec: make(chan *EnrichedEventExec, 64),
+		parents:      make(map[uint32]uint32),
+		comms:        make(map[uint32]string),
+	}
+
+	go en.run()
+
+	return en
+}
+
+// Close stops the enrichment loop and closes EnrichedExec.
+func (en *Enricher) Close() {
+	en.sub.Close()
+}
+
+func (en *Enricher) run() {
+	defer close(en.EnrichedExec)
+
+	for {
+		select {
+		case event, ok := <-en.sub.EventFork:
+			if !ok {
+				return
+			}
+			en.trackFork(event)
+		case event, ok := <-en.sub.EventExec:
+			if !ok {
+				return
+			}
+			en.EnrichedExec <- en.enrichExec(event)
+		}
+	}
+}
+
+func (en *Enricher) trackFork(event *EventFork) {
+	en.mu.Lock()
+	defer en.mu.Unlock()
+
+	en.parents[event.ChildPid] = event.ParentPid
+	en.rememberComm(event.ParentPid)
+}
+
+// rememberComm caches pid's comm, evicting the least recently cached entry
+// once the cache is full. Must be called with en.mu held.
+func (en *Enricher) rememberComm(pid uint32) {
+	if _, ok := en.comms[pid]; ok {
+		return
+	}
+
+	comm, err := readComm(pid)
+	if err != nil {
+		return
+	}
+
+	if len(en.lru) >= enrichCacheSize {
+		oldest := en.lru[0]
+		en.lru = en.lru[1:]
+		delete(en.comms, oldest)
+	}
+	en.comms[pid] = comm
+	en.lru = append(en.lru, pid)
+}
+
+func (en *Enricher) enrichExec(event *EventExec) *EnrichedEventExec {
+	enriched := &EnrichedEventExec{EventExec: *event}
+
+	comm, argv, exe, err := readProcMeta(event.Pid)
+	if err != nil {
+		// The process may already have exited by the time we read
+		// /proc (common for very short-lived children); fall back to
+		// whatever we cached from its fork event.
+		en.mu.Lock()
+		comm = en.comms[event.Pid]
+		en.mu.Unlock()
+	}
+
+	en.mu.Lock()
+	parent, known := en.parents[event.Pid]
+	en.mu.Unlock()
+
+	enriched.Comm = comm
+	enriched.Argv = argv
+	enriched.ExePath = exe
+	if known {
+		enriched.ParentPid = parent
+		enriched.Ancestors = en.ancestry(parent)
+	}
+
+	return enriched
+}
+
+// Ancestry returns the chain of ancestor pids for pid, nearest first, up to
+// pid 1 or the first unknown ancestor.
+func (en *Enricher) Ancestry(pid uint32) []uint32 {
+	en.mu.Lock()
+	defer en.mu.Unlock()
+	return en.ancestry(pid)
+}
+
+// ancestry must be called with en.mu held.
+func (en *Enricher) ancestry(pid uint32) []uint32 {
+	var chain []uint32
+	for pid != 0 && pid != 1 {
+		parent, ok := en.parents[pid]
+		if !ok {
+			break
+		}
+		chain = append(chain, parent)
+		pid = parent
+	}
+	return chain
+}
+
+func readComm(pid uint32) (string, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func readProcMeta(pid uint32) (comm string, argv []string, exe string, err error) {
+	comm, err = readComm(pid)
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	cmdline, err := os.ReadFile(fmt.Sprintf("/proc/%d/cmdline", pid))
+	if err != nil {
+		return "", nil, "", err
+	}
+	for _, arg := range bytes.Split(bytes.TrimRight(cmdline, "\x00"), []byte{0}) {
+		if len(arg) > 0 {
+			argv = append(argv, string(arg))
+		}
+	}
+
+	exe, err = os.Readlink(fmt.Sprintf("/proc/%d/exe", pid))
+	if err != nil {
+		// exe can be unreadable (permissions, zombie) even when comm
+		// and cmdline succeeded; that's not fatal to enrichment.
+		exe = ""
+	}
+
+	return comm, argv, exe, nil
+}
@@ -0,0 +1,71 @@
+package pmon
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestEnricherExecArgvAndComm(t *testing.T) {
+	tl := newTestListener(t)
+	defer tl.close()
+
+	en := NewEnricher(tl.listener)
+	defer en.Close()
+
+	cmd := exec.Command("sleep", "0.1")
+	if err := cmd.Run(); err != nil {
+		t.Fatal("Error on exec command:", err)
+	}
+	pid := uint32(cmd.Process.Pid)
+
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case event, ok := <-en.EnrichedExec:
+			if !ok {
+				t.Fatal("EnrichedExec closed before seeing expected pid")
+			}
+			if event.Pid != pid {
+				continue
+			}
+			if event.Comm != "sleep" {
+				t.Errorf("Expected comm %q, got %q", "sleep", event.Comm)
+			}
+			if len(event.Argv) < 2 || event.Argv[1] != "0.1" {
+				t.Errorf("Expected argv to include %q, got %v", "0.1", event.Argv)
+			}
+			return
+		case <-deadline:
+			t.Fatal("Timed out waiting for enriched exec event")
+		}
+	}
+}
+
+func TestEnricherAncestry(t *testing.T) {
+	tl := newTestListener(t)
+	defer tl.close()
+
+	en := NewEnricher(tl.listener)
+	defer en.Close()
+
+	parentPid := uint32(os.Getpid())
+	childPid := uint32(forkChild(t, 1000, 65534))
+
+	deadline := time.After(2 * time.Second)
+	for {
+		ancestors := en.Ancestry(childPid)
+		if len(ancestors) > 0 {
+			if ancestors[0] != parentPid {
+				t.Errorf("Expected immediate ancestor %d, got %d", parentPid, ancestors[0])
+			}
+			return
+		}
+		select {
+		case <-deadline:
+			t.Fatal("Timed out waiting for fork ancestry")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
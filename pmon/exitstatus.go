@@ -0,0 +1,63 @@
+package pmon
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// Status decodes Code into kernel wait-status semantics, distinguishing a
+// normal exit from death by signal the way syscall.WaitStatus does.
+//
+// proc_event's exit_code is already the packed status word wait(2) would
+// report: bits 8-15 hold the exit code for a normal exit, bits 0-6 hold the
+// terminating signal, and bit 7 is the core-dump flag. Signal is a
+// different field entirely (exit_signal, the signal sent to the parent,
+// normally SIGCHLD) and plays no part in this decode.
+func (e *EventExit) Status() syscall.WaitStatus {
+	return syscall.WaitStatus(e.Code)
+}
+
+// Exited reports whether the process terminated normally, e.g. via exit()
+// or falling off the end of main.
+func (e *EventExit) Exited() bool {
+	return e.Status().Exited()
+}
+
+// ExitStatus returns the exit code passed to exit(), or -1 if the process
+// did not exit normally.
+func (e *EventExit) ExitStatus() int {
+	return e.Status().ExitStatus()
+}
+
+// Signaled reports whether the process was terminated by a signal.
+func (e *EventExit) Signaled() bool {
+	return e.Status().Signaled()
+}
+
+// TermSignal returns the signal that terminated the process, or 0 if it
+// was not signaled.
+func (e *EventExit) TermSignal() syscall.Signal {
+	return e.Status().Signal()
+}
+
+// CoreDumped reports whether the terminating signal produced a core dump.
+func (e *EventExit) CoreDumped() bool {
+	return e.Status().CoreDump()
+}
+
+// String renders a short human-readable summary, e.g. "exited 0" or
+// "signaled terminated (core dumped)".
+func (e *EventExit) String() string {
+	status := e.Status()
+	switch {
+	case status.Exited():
+		return fmt.Sprintf("exited %d", status.ExitStatus())
+	case status.Signaled():
+		if status.CoreDump() {
+			return fmt.Sprintf("signaled %s (core dumped)", status.Signal())
+		}
+		return fmt.Sprintf("signaled %s", status.Signal())
+	default:
+		return fmt.Sprintf("exit status=%#x", e.Code)
+	}
+}
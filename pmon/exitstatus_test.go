@@ -0,0 +1,73 @@
+package pmon
+
+import (
+	"syscall"
+	"testing"
+)
+
+// Signal carries the proc connector's exit_signal, the signal sent to the
+// parent on exit (normally SIGCHLD), which is unrelated to how the process
+// terminated and must not affect Status's decode of Code.
+const toParentSignal = uint32(syscall.SIGCHLD)
+
+func TestEventExitNormal(t *testing.T) {
+	event := &EventExit{Pid: 1234, Tid: 1234, Code: 0, Signal: toParentSignal}
+
+	if !event.Exited() {
+		t.Errorf("Expected Exited() true for code=0")
+	}
+	if event.Signaled() {
+		t.Errorf("Expected Signaled() false for code=0")
+	}
+	if event.ExitStatus() != 0 {
+		t.Errorf("Expected ExitStatus() 0, got %d", event.ExitStatus())
+	}
+	if want := "exited 0"; event.String() != want {
+		t.Errorf("Expected String() %q, got %q", want, event.String())
+	}
+}
+
+func TestEventExitNonZeroCode(t *testing.T) {
+	event := &EventExit{Pid: 1234, Tid: 1234, Code: 15 << 8, Signal: toParentSignal}
+
+	if event.ExitStatus() != 15 {
+		t.Errorf("Expected ExitStatus() 15, got %d", event.ExitStatus())
+	}
+	if event.Signaled() {
+		t.Errorf("Expected Signaled() false for a normal non-zero exit")
+	}
+}
+
+func TestEventExitSignaled(t *testing.T) {
+	event := &EventExit{Pid: 1234, Tid: 1234, Code: uint32(syscall.SIGTERM), Signal: toParentSignal}
+
+	if !event.Signaled() {
+		t.Errorf("Expected Signaled() true for a SIGTERM death")
+	}
+	if event.Exited() {
+		t.Errorf("Expected Exited() false for a signaled process")
+	}
+	if event.TermSignal() != syscall.SIGTERM {
+		t.Errorf("Expected TermSignal() SIGTERM, got %v", event.TermSignal())
+	}
+	if event.CoreDumped() {
+		t.Errorf("Expected CoreDumped() false without the core-dump bit set")
+	}
+	if want := "signaled terminated"; event.String() != want {
+		t.Errorf("Expected String() %q, got %q", want, event.String())
+	}
+}
+
+func TestEventExitSignaledWithCoreDump(t *testing.T) {
+	event := &EventExit{Pid: 1234, Tid: 1234, Code: uint32(syscall.SIGSEGV) | 0x80, Signal: toParentSignal}
+
+	if !event.Signaled() {
+		t.Errorf("Expected Signaled() true for a SIGSEGV death")
+	}
+	if !event.CoreDumped() {
+		t.Errorf("Expected CoreDumped() true with the core-dump bit set")
+	}
+	if want := "signaled segmentation fault (core dumped)"; event.String() != want {
+		t.Errorf("Expected String() %q, got %q", want, event.String())
+	}
+}
@@ -0,0 +1,319 @@
+package pmon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Filter selects which process events a Subscription receives. The zero
+// value matches everything.
+type Filter struct {
+	// Pids restricts delivery to this set of pids. When FollowForks is
+	// set, children of a tracked pid are added to the set automatically
+	// as fork events arrive.
+	Pids []uint32
+
+	// FollowForks expands Pids to include the children of any pid
+	// already in the set.
+	FollowForks bool
+
+	// PidNamespaces restricts delivery to processes living in one of
+	// these PID namespace inode numbers (see /proc/<pid>/ns/pid).
+	PidNamespaces []uint64
+
+	// CgroupGlobs restricts delivery to processes whose cgroup v2 path
+	// (the unified line of /proc/<pid>/cgroup) matches one of these
+	// filepath.Match globs, e.g. "/system.slice/docker-*.scope".
+	CgroupGlobs []string
+
+	// Uids, when non-empty, restricts delivery to EventUid for processes
+	// whose real uid is one of this exact set. Takes precedence over
+	// UidMin/UidMax.
+	Uids []uint32
+
+	// UidMin/UidMax, when UidMax is non-zero and Uids is empty, restrict
+	// delivery to EventUid for processes whose real uid falls in
+	// [UidMin, UidMax].
+	UidMin, UidMax uint32
+
+	// GidMin/GidMax mirror UidMin/UidMax for gid.
+	GidMin, GidMax uint32
+
+	// ExcludeSelf drops events originating from this process or any of
+	// its descendants.
+	ExcludeSelf bool
+}
+
+// Subscription delivers the subset of a ProcListener's events matching a
+// Filter on typed channels mirroring the ProcListener surface.
+type Subscription struct {
+	listener *ProcListener
+
+	EventFork chan *EventFork
+	EventExec chan *EventExec
+	EventUid  chan *EventUid
+	EventGid  chan *EventGid
+	EventSid  chan *EventSid
+	EventExit chan *EventExit
+
+	mu        sync.Mutex
+	filter    Filter
+	pids      map[uint32]bool
+	uids      map[uint32]bool
+	selfTree  map[uint32]bool
+	closeOnce sync.Once
+	done      chan struct{}
+	dropped   uint64
+}
+
+// Subscribe registers filter with pl and returns a Subscription delivering
+// only the events it matches. The subscription must be stopped with
+// Subscription.Close once the caller is done draining it.
+func (pl *ProcListener) Subscribe(filter Filter) *Subscription {
+	sub := &Subscription{
+		listener:  pl,
+		EventFork: make(chan *EventFork, 64),
+		EventExec: make(chan *EventExec, 64),
+		EventUid:  make(chan *EventUid, 64),
+		EventGid:  make(chan *EventGid, 64),
+		EventSid:  make(chan *EventSid, 64),
+		EventExit: make(chan *EventExit, 64),
+		filter:    filter,
+		pids:      make(map[uint32]bool, len(filter.Pids)),
+		uids:      make(map[uint32]bool, len(filter.Uids)),
+		selfTree:  map[uint32]bool{uint32(os.Getpid()): true},
+		done:      make(chan struct{}),
+	}
+	for _, pid := range filter.Pids {
+		sub.pids[pid] = true
+	}
+	for _, uid := range filter.Uids {
+		sub.uids[uid] = true
+	}
+
+	pl.mu.Lock()
+	pl.subs = append(pl.subs, sub)
+	pl.mu.Unlock()
+
+	return sub
+}
+
+// Close unregisters the subscription. Its channels are closed; further
+// sends from the dispatch loop are dropped.
+func (sub *Subscription) Close() {
+	sub.closeOnce.Do(func() {
+		close(sub.done)
+
+		pl := sub.listener
+		pl.mu.Lock()
+		for i, s := range pl.subs {
+			if s == sub {
+				pl.subs = append(pl.subs[:i], pl.subs[i+1:]...)
+				break
+			}
+		}
+		pl.mu.Unlock()
+
+		close(sub.EventFork)
+		close(sub.EventExec)
+		close(sub.EventUid)
+		close(sub.EventGid)
+		close(sub.EventSid)
+		close(sub.EventExit)
+	})
+}
+
+// Dropped returns the number of events dropped because this subscription's
+// channel was full at the moment dispatch tried to deliver them.
+func (sub *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&sub.dropped)
+}
+
+func (sub *Subscription) stopped() bool {
+	select {
+	case <-sub.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// trackFork expands the tracked pid set when FollowForks is set and
+// maintains the ExcludeSelf descendant tree.
+func (sub *Subscription) trackFork(event *EventFork) {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	if sub.selfTree[event.ParentPid] {
+		sub.selfTree[event.ChildPid] = true
+	}
+	if sub.filter.FollowForks && sub.pids[event.ParentPid] {
+		sub.pids[event.ChildPid] = true
+	}
+}
+
+func (sub *Subscription) matchesPid(pid uint32) bool {
+	if len(sub.filter.Pids) == 0 {
+		return true
+	}
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	return sub.pids[pid]
+}
+
+func (sub *Subscription) matchesSelf(pid uint32) bool {
+	if !sub.filter.ExcludeSelf {
+		return true
+	}
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	return !sub.selfTree[pid]
+}
+
+func (sub *Subscription) matchesUid(uid uint32) bool {
+	if len(sub.filter.Uids) > 0 {
+		return sub.uids[uid]
+	}
+	if sub.filter.UidMax == 0 {
+		return true
+	}
+	return uid >= sub.filter.UidMin && uid <= sub.filter.UidMax
+}
+
+func (sub *Subscription) matchesGid(gid uint32) bool {
+	if sub.filter.GidMax == 0 {
+		return true
+	}
+	return gid >= sub.filter.GidMin && gid <= sub.filter.GidMax
+}
+
+func (sub *Subscription) matchesNamespace(pid uint32) bool {
+	if len(sub.filter.PidNamespaces) == 0 {
+		return true
+	}
+	ns, err := pidNamespace(pid)
+	if err != nil {
+		return false
+	}
+	for _, want := range sub.filter.PidNamespaces {
+		if ns == want {
+			return true
+		}
+	}
+	return false
+}
+
+func (sub *Subscription) matchesCgroup(pid uint32) bool {
+	if len(sub.filter.CgroupGlobs) == 0 {
+		return true
+	}
+	path, err := cgroupPath(pid)
+	if err != nil {
+		return false
+	}
+	for _, glob := range sub.filter.CgroupGlobs {
+		if ok, _ := filepath.Match(glob, path); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (sub *Subscription) matches(pid uint32) bool {
+	return sub.matchesPid(pid) && sub.matchesSelf(pid) && sub.matchesNamespace(pid) && sub.matchesCgroup(pid)
+}
+
+// pidNamespace resolves the PID namespace inode of pid via its
+// /proc/<pid>/ns/pid symlink target, e.g. "pid:[4026531836]".
+func pidNamespace(pid uint32) (uint64, error) {
+	link, err := os.Readlink(fmt.Sprintf("/proc/%d/ns/pid", pid))
+	if err != nil {
+		return 0, err
+	}
+	start := strings.IndexByte(link, '[')
+	end := strings.IndexByte(link, ']')
+	if start < 0 || end < 0 || end <= start {
+		return 0, fmt.Errorf("pmon: malformed ns/pid link %q", link)
+	}
+	return strconv.ParseUint(link[start+1:end], 10, 64)
+}
+
+// cgroupPath returns the unified (v2) cgroup path for pid, as reported by
+// the single "0::<path>" line of /proc/<pid>/cgroup.
+func cgroupPath(pid uint32) (string, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if strings.HasPrefix(line, "0::") {
+			return strings.TrimPrefix(line, "0::"), nil
+		}
+	}
+	return "", fmt.Errorf("pmon: no v2 cgroup line for pid %d", pid)
+}
+
+// dispatch fans event out to every subscription whose filter matches it. It
+// never blocks on a full subscription channel: a value that can't be sent
+// immediately is dropped rather than stalling the connector read loop.
+func (pl *ProcListener) dispatch(event interface{}) {
+	pl.mu.Lock()
+	subs := append([]*Subscription(nil), pl.subs...)
+	pl.mu.Unlock()
+
+	for _, sub := range subs {
+		if sub.stopped() {
+			continue
+		}
+
+		switch e := event.(type) {
+		case *EventFork:
+			sub.trackFork(e)
+			if sub.matches(e.ChildPid) || sub.matches(e.ParentPid) {
+				trackedSend(sub, sub.EventFork, e)
+			}
+		case *EventExec:
+			if sub.matches(e.Pid) {
+				trackedSend(sub, sub.EventExec, e)
+			}
+		case *EventUid:
+			if sub.matches(e.Pid) && sub.matchesUid(e.Ruid) {
+				trackedSend(sub, sub.EventUid, e)
+			}
+		case *EventGid:
+			if sub.matches(e.Pid) && sub.matchesGid(e.Rgid) {
+				trackedSend(sub, sub.EventGid, e)
+			}
+		case *EventSid:
+			if sub.matches(e.Pid) {
+				trackedSend(sub, sub.EventSid, e)
+			}
+		case *EventExit:
+			if sub.matches(e.Pid) {
+				trackedSend(sub, sub.EventExit, e)
+			}
+		}
+	}
+}
+
+// trackedSend delivers v on ch, counting it in sub.dropped rather than
+// blocking if ch is full.
+func trackedSend[T any](sub *Subscription, ch chan T, v T) {
+	if !trySend(ch, v) {
+		atomic.AddUint64(&sub.dropped, 1)
+	}
+}
+
+func trySend[T any](ch chan T, v T) bool {
+	select {
+	case ch <- v:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,281 @@
+package pmon
+
+import (
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// testSubscription drains a Subscription in the background, the same way
+// testListener drains a bare ProcListener in netlink_test.go.
+type testSubscription struct {
+	t    *testing.T
+	sub  *Subscription
+	done chan bool
+
+	forks []EventFork
+	execs []EventExec
+	uids  []EventUid
+	gids  []EventGid
+	sids  []EventSid
+	exits []EventExit
+}
+
+func newTestSubscription(t *testing.T, tl *testListener, filter Filter) *testSubscription {
+	ts := &testSubscription{
+		t:    t,
+		sub:  tl.listener.Subscribe(filter),
+		done: make(chan bool, 1),
+	}
+
+	go func() {
+		for {
+			select {
+			case <-ts.done:
+				return
+			case event, ok := <-ts.sub.EventFork:
+				if ok {
+					ts.forks = append(ts.forks, *event)
+				}
+			case event, ok := <-ts.sub.EventExec:
+				if ok {
+					ts.execs = append(ts.execs, *event)
+				}
+			case event, ok := <-ts.sub.EventUid:
+				if ok {
+					ts.uids = append(ts.uids, *event)
+				}
+			case event, ok := <-ts.sub.EventGid:
+				if ok {
+					ts.gids = append(ts.gids, *event)
+				}
+			case event, ok := <-ts.sub.EventSid:
+				if ok {
+					ts.sids = append(ts.sids, *event)
+				}
+			case event, ok := <-ts.sub.EventExit:
+				if ok {
+					ts.exits = append(ts.exits, *event)
+				}
+			}
+		}
+	}()
+
+	return ts
+}
+
+func (ts *testSubscription) close() {
+	pause := 100 * time.Millisecond
+	time.Sleep(pause)
+	ts.done <- true
+	ts.sub.Close()
+}
+
+var (
+	helperBinaryOnce sync.Once
+	helperBinaryPath string
+	helperBinaryErr  error
+)
+
+// prepareHelperBinary copies this test binary to a world-executable temp
+// file, so forkChild's Credential can drop to a non-root uid/gid across the
+// exec: go test builds into a private (mode 0700) temp directory that a
+// dropped-privilege uid can't traverse to exec the original path.
+func prepareHelperBinary() (string, error) {
+	helperBinaryOnce.Do(func() {
+		self, err := os.Executable()
+		if err != nil {
+			helperBinaryErr = err
+			return
+		}
+		data, err := os.ReadFile(self)
+		if err != nil {
+			helperBinaryErr = err
+			return
+		}
+		f, err := os.CreateTemp("", "pmon-test-helper-*")
+		if err != nil {
+			helperBinaryErr = err
+			return
+		}
+		defer f.Close()
+		if _, err := f.Write(data); err != nil {
+			helperBinaryErr = err
+			return
+		}
+		helperBinaryErr = f.Chmod(0o755)
+		helperBinaryPath = f.Name()
+	})
+	return helperBinaryPath, helperBinaryErr
+}
+
+// forkChild starts a short-lived child running as childUid/childGid in a
+// new session, so tests can observe its fork/uid/gid/sid/exit events. It
+// re-execs a copy of this test binary into TestHelperProcess rather than
+// forking the Go runtime directly: Go's runtime is multi-threaded, and
+// calling raw SYS_FORK then continuing to run Go code (time.Sleep,
+// os.Exit) in the child is unsafe and was observed to corrupt later tests
+// in this package.
+func forkChild(t *testing.T, childUid, childGid int) int {
+	helper, err := prepareHelperBinary()
+	if err != nil {
+		t.Fatal("Error preparing helper binary:", err)
+	}
+
+	cmd := exec.Command(helper, "-test.run=^TestHelperProcess$")
+	cmd.Env = append(os.Environ(), "GO_WANT_HELPER_PROCESS=1")
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setsid:     true,
+		Credential: &syscall.Credential{Uid: uint32(childUid), Gid: uint32(childGid)},
+	}
+
+	if err := cmd.Start(); err != nil {
+		t.Fatal("Error starting helper process:", err)
+	}
+	go cmd.Wait()
+
+	return cmd.Process.Pid
+}
+
+// TestHelperProcess is not a real test; forkChild re-execs into it to get a
+// child process that outlives cmd.Start() by a beat, then exits. It only
+// does anything when GO_WANT_HELPER_PROCESS is set.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	defer os.Exit(0)
+	time.Sleep(100 * time.Millisecond)
+}
+
+func TestSubscriptionDroppedCountsFullChannel(t *testing.T) {
+	pl := &ProcListener{}
+	sub := pl.Subscribe(Filter{})
+	defer sub.Close()
+
+	// EventFork has capacity 64; fill it past capacity without draining
+	// so dispatch has no choice but to drop the overflow.
+	for i := 0; i < cap(sub.EventFork)+1; i++ {
+		pl.dispatch(&EventFork{ParentPid: 1, ChildPid: uint32(i)})
+	}
+
+	if sub.Dropped() == 0 {
+		t.Errorf("Expected Dropped() > 0 once the subscription's channel filled up")
+	}
+}
+
+func TestFilterByPid(t *testing.T) {
+	tl := newTestListener(t)
+	childPid := forkChild(t, 1000, 65534)
+	ts := newTestSubscription(t, tl, Filter{Pids: []uint32{uint32(childPid)}})
+
+	tl.close()
+	ts.close()
+
+	for _, event := range ts.uids {
+		if event.Pid != uint32(childPid) {
+			t.Errorf("Expected only events for pid %d, got %d", childPid, event.Pid)
+		}
+	}
+}
+
+func TestFilterByUidRange(t *testing.T) {
+	tl := newTestListener(t)
+	wantedUid := 1000
+	otherUid := 1001
+	ts := newTestSubscription(t, tl, Filter{UidMin: 1000, UidMax: 1000})
+
+	wantedPid := forkChild(t, wantedUid, 65534)
+	otherPid := forkChild(t, otherUid, 65534)
+
+	tl.close()
+	ts.close()
+
+	found := false
+	for _, event := range ts.uids {
+		if event.Pid == uint32(otherPid) {
+			t.Errorf("Did not expect event for pid %d outside uid range", otherPid)
+		}
+		if event.Pid == uint32(wantedPid) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected uid event for pid %d", wantedPid)
+	}
+}
+
+func TestFilterByUidSet(t *testing.T) {
+	tl := newTestListener(t)
+	wantedUid := 1000
+	betweenUid := 1500
+	otherUid := 2000
+	ts := newTestSubscription(t, tl, Filter{Uids: []uint32{uint32(wantedUid), uint32(otherUid)}})
+
+	wantedPid := forkChild(t, wantedUid, 65534)
+	betweenPid := forkChild(t, betweenUid, 65534)
+	otherPid := forkChild(t, otherUid, 65534)
+
+	tl.close()
+	ts.close()
+
+	found := false
+	for _, event := range ts.uids {
+		if event.Pid == uint32(betweenPid) {
+			t.Errorf("Did not expect event for pid %d outside the uid set", betweenPid)
+		}
+		if event.Pid == uint32(wantedPid) || event.Pid == uint32(otherPid) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected uid events for pids %d and %d", wantedPid, otherPid)
+	}
+}
+
+func TestFilterExcludeSelf(t *testing.T) {
+	tl := newTestListener(t)
+	ts := newTestSubscription(t, tl, Filter{ExcludeSelf: true})
+
+	cmd := exec.Command("sleep", "0.1")
+	if err := cmd.Run(); err != nil {
+		t.Fatal("Error on exec command:", err)
+	}
+	pid := uint32(cmd.Process.Pid)
+
+	tl.close()
+	ts.close()
+
+	for _, event := range ts.execs {
+		if event.Pid == pid {
+			t.Errorf("Did not expect exec event for excluded self-tree pid %d", pid)
+		}
+	}
+}
+
+func TestFilterFollowForks(t *testing.T) {
+	tl := newTestListener(t)
+	parentPid := os.Getpid()
+	ts := newTestSubscription(t, tl, Filter{
+		Pids:        []uint32{uint32(parentPid)},
+		FollowForks: true,
+	})
+
+	childPid := forkChild(t, 1000, 65534)
+
+	tl.close()
+	ts.close()
+
+	childSeen := false
+	for _, event := range ts.forks {
+		if event.ChildPid == uint32(childPid) {
+			childSeen = true
+		}
+	}
+	if !childSeen {
+		t.Errorf("Expected fork event for followed child pid %d", childPid)
+	}
+}
@@ -0,0 +1,48 @@
+package grpc_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+
+	"google.golang.org/grpc"
+
+	"github.com/vsurjaninov/procev/pmon/grpc/procevpb"
+)
+
+// Example_client shows the shape of a remote subscriber: dial the server,
+// open a Subscribe stream for a set of pids, and print decoded events until
+// the stream ends.
+func Example_client() {
+	conn, err := grpc.Dial("unix:///run/procev.sock", grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+
+	client := procevpb.NewProcEventsClient(conn)
+	stream, err := client.Subscribe(context.Background(), &procevpb.SubscribeRequest{
+		EventMask: uint32(procevpb.EventType_EVENT_TYPE_EXEC) | uint32(procevpb.EventType_EVENT_TYPE_EXIT),
+	})
+	if err != nil {
+		log.Fatalf("subscribe: %v", err)
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			log.Fatalf("recv: %v", err)
+		}
+
+		switch e := event.GetEvent().(type) {
+		case *procevpb.ProcEvent_Exec:
+			fmt.Printf("exec pid=%d\n", e.Exec.Pid)
+		case *procevpb.ProcEvent_Exit:
+			fmt.Printf("exit pid=%d code=%d signal=%d\n", e.Exit.Pid, e.Exit.Code, e.Exit.Signal)
+		}
+	}
+}
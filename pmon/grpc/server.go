@@ -0,0 +1,129 @@
+// Package grpc wraps a pmon.ProcListener behind a gRPC streaming service so
+// remote monitors, containers, or sidecars can consume process events
+// without needing CAP_NET_ADMIN themselves.
+package grpc
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/vsurjaninov/procev/pmon"
+	"github.com/vsurjaninov/procev/pmon/grpc/procevpb"
+)
+
+// Server implements procevpb.ProcEventsServer on top of a pmon.ProcListener.
+type Server struct {
+	procevpb.UnimplementedProcEventsServer
+
+	listener *pmon.ProcListener
+
+	delivered uint64
+	// dropped accumulates, across every Subscribe stream served so far,
+	// both transport-level drops (a stream.Send failure, which also ends
+	// that RPC) and the underlying Subscription's own backpressure drops
+	// (events the dispatch loop couldn't deliver because that stream's
+	// channel was full).
+	dropped uint64
+}
+
+// NewServer returns a Server streaming events from listener. listener must
+// already be connected and have ListenEvents running.
+func NewServer(listener *pmon.ProcListener) *Server {
+	return &Server{listener: listener}
+}
+
+// Subscribe implements procevpb.ProcEventsServer. It registers a
+// pmon.Filter derived from req and streams matching events until the
+// client disconnects.
+func (s *Server) Subscribe(req *procevpb.SubscribeRequest, stream procevpb.ProcEvents_SubscribeServer) error {
+	sub := s.listener.Subscribe(filterFromRequest(req))
+	defer sub.Close()
+	defer func() { atomic.AddUint64(&s.dropped, sub.Dropped()) }()
+
+	mask := req.GetEventMask()
+	wants := func(bit procevpb.EventType) bool {
+		return mask == 0 || mask&uint32(bit) != 0
+	}
+
+	for {
+		var event *procevpb.ProcEvent
+
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case e, ok := <-sub.EventFork:
+			if !ok {
+				return nil
+			}
+			if wants(procevpb.EventType_EVENT_TYPE_FORK) {
+				event = &procevpb.ProcEvent{Event: &procevpb.ProcEvent_Fork{Fork: &procevpb.ForkEvent{
+					ParentPid: e.ParentPid, ParentTid: e.ParentTid, ChildPid: e.ChildPid, ChildTid: e.ChildTid,
+				}}}
+			}
+		case e, ok := <-sub.EventExec:
+			if !ok {
+				return nil
+			}
+			if wants(procevpb.EventType_EVENT_TYPE_EXEC) {
+				event = &procevpb.ProcEvent{Event: &procevpb.ProcEvent_Exec{Exec: &procevpb.ExecEvent{Pid: e.Pid, Tid: e.Tid}}}
+			}
+		case e, ok := <-sub.EventUid:
+			if !ok {
+				return nil
+			}
+			if wants(procevpb.EventType_EVENT_TYPE_UID) {
+				event = &procevpb.ProcEvent{Event: &procevpb.ProcEvent_Uid{Uid: &procevpb.UidEvent{
+					Pid: e.Pid, Tid: e.Tid, Ruid: e.Ruid, Euid: e.Euid,
+				}}}
+			}
+		case e, ok := <-sub.EventGid:
+			if !ok {
+				return nil
+			}
+			if wants(procevpb.EventType_EVENT_TYPE_GID) {
+				event = &procevpb.ProcEvent{Event: &procevpb.ProcEvent_Gid{Gid: &procevpb.GidEvent{
+					Pid: e.Pid, Tid: e.Tid, Rgid: e.Rgid, Egid: e.Egid,
+				}}}
+			}
+		case e, ok := <-sub.EventSid:
+			if !ok {
+				return nil
+			}
+			if wants(procevpb.EventType_EVENT_TYPE_SID) {
+				event = &procevpb.ProcEvent{Event: &procevpb.ProcEvent_Sid{Sid: &procevpb.SidEvent{Pid: e.Pid, Tid: e.Tid}}}
+			}
+		case e, ok := <-sub.EventExit:
+			if !ok {
+				return nil
+			}
+			if wants(procevpb.EventType_EVENT_TYPE_EXIT) {
+				event = &procevpb.ProcEvent{Event: &procevpb.ProcEvent_Exit{Exit: &procevpb.ExitEvent{
+					Pid: e.Pid, Tid: e.Tid, Code: e.Code, Signal: e.Signal,
+				}}}
+			}
+		}
+
+		if event == nil {
+			continue
+		}
+
+		if err := stream.Send(event); err != nil {
+			atomic.AddUint64(&s.dropped, 1)
+			return err
+		}
+		atomic.AddUint64(&s.delivered, 1)
+	}
+}
+
+// Status implements procevpb.ProcEventsServer, reporting cumulative
+// delivered/dropped counters across every Subscribe stream served so far.
+func (s *Server) Status(ctx context.Context, req *procevpb.StatusRequest) (*procevpb.StatusReply, error) {
+	return &procevpb.StatusReply{
+		Delivered: atomic.LoadUint64(&s.delivered),
+		Dropped:   atomic.LoadUint64(&s.dropped),
+	}, nil
+}
+
+func filterFromRequest(req *procevpb.SubscribeRequest) pmon.Filter {
+	return pmon.Filter{Pids: req.GetPids(), Uids: req.GetUids()}
+}
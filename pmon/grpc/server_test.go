@@ -0,0 +1,84 @@
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"os/exec"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/vsurjaninov/procev/pmon"
+	procevgrpc "github.com/vsurjaninov/procev/pmon/grpc"
+	"github.com/vsurjaninov/procev/pmon/grpc/procevpb"
+)
+
+const bufSize = 1024 * 1024
+
+func startBufconnServer(t *testing.T, listener *pmon.ProcListener) (procevpb.ProcEventsClient, func()) {
+	lis := bufconn.Listen(bufSize)
+	grpcServer := grpc.NewServer()
+	procevpb.RegisterProcEventsServer(grpcServer, procevgrpc.NewServer(listener))
+
+	go grpcServer.Serve(lis)
+
+	dialer := func(context.Context, string) (net.Conn, error) { return lis.Dial() }
+	conn, err := grpc.DialContext(context.Background(), "bufconn",
+		grpc.WithContextDialer(dialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial bufconn: %v", err)
+	}
+
+	return procevpb.NewProcEventsClient(conn), func() {
+		conn.Close()
+		grpcServer.Stop()
+	}
+}
+
+func TestSubscribeRoundTripsExecAndExit(t *testing.T) {
+	listener := &pmon.ProcListener{}
+	if err := listener.Connect(); err != nil {
+		t.Fatal("Failed connect")
+	}
+	defer listener.Close()
+	go listener.ListenEvents()
+
+	client, stop := startBufconnServer(t, listener)
+	defer stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.Subscribe(ctx, &procevpb.SubscribeRequest{})
+	if err != nil {
+		t.Fatal("Failed to subscribe:", err)
+	}
+
+	cmd := exec.Command("sleep", "0.1")
+	if err := cmd.Run(); err != nil {
+		t.Fatal("Error on exec command:", err)
+	}
+	pid := uint32(cmd.Process.Pid)
+
+	execSeen, exitSeen := false, false
+	for !execSeen || !exitSeen {
+		event, err := stream.Recv()
+		if err != nil {
+			t.Fatal("Error on stream recv:", err)
+		}
+
+		switch e := event.GetEvent().(type) {
+		case *procevpb.ProcEvent_Exec:
+			if e.Exec.Pid == pid {
+				execSeen = true
+			}
+		case *procevpb.ProcEvent_Exit:
+			if e.Exit.Pid == pid {
+				exitSeen = true
+			}
+		}
+	}
+}
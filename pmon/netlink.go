@@ -0,0 +1,346 @@
+// Package pmon implements a client for the Linux process events connector,
+// delivering fork/exec/exit/uid/gid/sid notifications over a netlink socket.
+package pmon
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+const (
+	_CN_IDX_PROC = 0x1
+	_CN_VAL_PROC = 0x1
+
+	_PROC_CN_MCAST_LISTEN = 1
+	_PROC_CN_MCAST_IGNORE = 2
+
+	_PROC_EVENT_NONE = 0x00000000
+	_PROC_EVENT_FORK = 0x00000001
+	_PROC_EVENT_EXEC = 0x00000002
+	_PROC_EVENT_UID  = 0x00000004
+	_PROC_EVENT_GID  = 0x00000040
+	_PROC_EVENT_SID  = 0x00000080
+	_PROC_EVENT_EXIT = 0x80000000
+)
+
+// cnMsgHeader mirrors struct cn_msg from <linux/connector.h>, minus the
+// trailing variable-length data payload.
+type cnMsgHeader struct {
+	Idx   uint32
+	Val   uint32
+	Seq   uint32
+	Ack   uint32
+	Len   uint16
+	Flags uint16
+}
+
+// EventAck reports that the kernel has acknowledged our listen/ignore
+// request on the proc connector multicast group.
+type EventAck struct {
+	No uint32
+}
+
+// EventFork is delivered when a process calls fork/vfork/clone.
+type EventFork struct {
+	ParentPid, ParentTid uint32
+	ChildPid, ChildTid   uint32
+}
+
+// EventExec is delivered when a process successfully calls execve.
+type EventExec struct {
+	Pid, Tid uint32
+}
+
+// EventUid is delivered when a process's real or effective uid changes.
+type EventUid struct {
+	Pid, Tid   uint32
+	Ruid, Euid uint32
+}
+
+// EventGid is delivered when a process's real or effective gid changes.
+type EventGid struct {
+	Pid, Tid   uint32
+	Rgid, Egid uint32
+}
+
+// EventSid is delivered when a process calls setsid.
+type EventSid struct {
+	Pid, Tid uint32
+}
+
+// EventExit is delivered when a process exits.
+type EventExit struct {
+	Pid, Tid uint32
+	Code     uint32
+	Signal   uint32
+}
+
+// ProcListener connects to the kernel's process events connector and fans
+// out decoded events on the typed channels below.
+//
+// Sends on the per-type event channels are non-blocking: a decoded event is
+// offered to whichever of them is being drained, and dropped for it
+// otherwise, rather than stalling ListenEvents. Callers that only want a
+// subset of events, or that never read these channels at all (e.g. because
+// they use Subscribe or RegisterSink instead), do not need to drain them.
+type ProcListener struct {
+	sock int
+
+	EventAck     chan *EventAck
+	EventFork    chan *EventFork
+	EventExec    chan *EventExec
+	EventUid     chan *EventUid
+	EventGid     chan *EventGid
+	EventSid     chan *EventSid
+	EventExit    chan *EventExit
+	EventDropped chan *EventDropped
+	Error        chan error
+
+	mu    sync.Mutex
+	subs  []*Subscription
+	sinks []*sinkRegistration
+
+	recvBuf int
+
+	seqMu      sync.Mutex
+	haveSeq    bool
+	lastSeq    uint32
+	received   uint64
+	dropped    uint64
+	overflowed uint64
+}
+
+// Connect opens the netlink socket, binds it to the connector protocol, and
+// subscribes to the proc connector multicast group. It requires
+// CAP_NET_ADMIN.
+func (pl *ProcListener) Connect() error {
+	sock, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_DGRAM, syscall.NETLINK_CONNECTOR)
+	if err != nil {
+		return fmt.Errorf("pmon: socket: %w", err)
+	}
+
+	// Pid 0 lets the kernel autoassign a unique netlink port id; pinning
+	// it to os.Getpid() would collide when a process opens more than one
+	// ProcListener (e.g. sequential tests in the same package).
+	addr := &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK, Groups: _CN_IDX_PROC, Pid: 0}
+	if err := syscall.Bind(sock, addr); err != nil {
+		syscall.Close(sock)
+		return fmt.Errorf("pmon: bind: %w", err)
+	}
+
+	pl.sock = sock
+	pl.EventAck = make(chan *EventAck)
+	pl.EventFork = make(chan *EventFork)
+	pl.EventExec = make(chan *EventExec)
+	pl.EventUid = make(chan *EventUid)
+	pl.EventGid = make(chan *EventGid)
+	pl.EventSid = make(chan *EventSid)
+	pl.EventExit = make(chan *EventExit)
+	pl.EventDropped = make(chan *EventDropped)
+	pl.Error = make(chan error)
+
+	if pl.recvBuf > 0 {
+		if err := pl.applyRecvBuffer(pl.recvBuf); err != nil {
+			syscall.Close(sock)
+			return err
+		}
+	}
+
+	if err := pl.setListen(_PROC_CN_MCAST_LISTEN); err != nil {
+		syscall.Close(sock)
+		return err
+	}
+
+	return nil
+}
+
+func (pl *ProcListener) setListen(op uint32) error {
+	hdr := cnMsgHeader{Idx: _CN_IDX_PROC, Val: _CN_VAL_PROC, Len: 4}
+
+	buf := make([]byte, 0, nlMsgHdrLen+cnMsgHdrLen+4)
+	buf = appendNlMsgHdr(buf, uint32(cnMsgHdrLen+4))
+	buf = appendCnMsgHdr(buf, hdr)
+	buf = binary.LittleEndian.AppendUint32(buf, op)
+
+	return syscall.Sendto(pl.sock, buf, 0, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK})
+}
+
+// ListenEvents blocks, reading and decoding events until the socket is
+// closed. Decoded events are delivered on the per-type channels; decode and
+// recv errors are delivered on Error.
+func (pl *ProcListener) ListenEvents() {
+	buf := make([]byte, 4096)
+	for {
+		n, _, err := syscall.Recvfrom(pl.sock, buf, 0)
+		if err != nil {
+			if err == syscall.EBADF || err == syscall.EINVAL {
+				return
+			}
+			if err == syscall.ENOBUFS {
+				atomic.AddUint64(&pl.overflowed, 1)
+			}
+			pl.Error <- fmt.Errorf("pmon: recvfrom: %w", err)
+			continue
+		}
+
+		if err := pl.decode(buf[:n]); err != nil {
+			pl.Error <- err
+		}
+	}
+}
+
+func (pl *ProcListener) decode(raw []byte) error {
+	msgs, err := syscall.ParseNetlinkMessage(raw)
+	if err != nil {
+		return fmt.Errorf("pmon: parse netlink message: %w", err)
+	}
+
+	for _, msg := range msgs {
+		if len(msg.Data) < cnMsgHdrLen {
+			continue
+		}
+
+		hdr := parseCnMsgHdr(msg.Data)
+		data := msg.Data[cnMsgHdrLen:]
+		if len(data) < 4 {
+			continue
+		}
+
+		if hdr.Ack != 0 && len(data) == 4 {
+			trySend(pl.EventAck, &EventAck{No: binary.LittleEndian.Uint32(data)})
+			continue
+		}
+
+		pl.trackSeq(hdr.Seq)
+		pl.decodeProcEvent(binary.LittleEndian.Uint32(data), data[16:])
+	}
+
+	return nil
+}
+
+// decodeProcEvent decodes a struct proc_event payload. body starts right
+// after the `what`/`cpu`/`timestamp_ns` header (4 + 4 + 8 bytes).
+func (pl *ProcListener) decodeProcEvent(what uint32, body []byte) {
+	switch what {
+	case _PROC_EVENT_FORK:
+		if len(body) < 16 {
+			return
+		}
+		event := &EventFork{
+			ParentTid: binary.LittleEndian.Uint32(body[0:4]),
+			ParentPid: binary.LittleEndian.Uint32(body[4:8]),
+			ChildTid:  binary.LittleEndian.Uint32(body[8:12]),
+			ChildPid:  binary.LittleEndian.Uint32(body[12:16]),
+		}
+		trySend(pl.EventFork, event)
+		pl.dispatch(event)
+		pl.fanOut(Event{Kind: KindFork, Time: time.Now(), Fork: event})
+	case _PROC_EVENT_EXEC:
+		if len(body) < 8 {
+			return
+		}
+		event := &EventExec{
+			Tid: binary.LittleEndian.Uint32(body[0:4]),
+			Pid: binary.LittleEndian.Uint32(body[4:8]),
+		}
+		trySend(pl.EventExec, event)
+		pl.dispatch(event)
+		pl.fanOut(Event{Kind: KindExec, Time: time.Now(), Exec: event})
+	case _PROC_EVENT_UID:
+		if len(body) < 16 {
+			return
+		}
+		event := &EventUid{
+			Tid:  binary.LittleEndian.Uint32(body[0:4]),
+			Pid:  binary.LittleEndian.Uint32(body[4:8]),
+			Ruid: binary.LittleEndian.Uint32(body[8:12]),
+			Euid: binary.LittleEndian.Uint32(body[12:16]),
+		}
+		trySend(pl.EventUid, event)
+		pl.dispatch(event)
+		pl.fanOut(Event{Kind: KindUid, Time: time.Now(), Uid: event})
+	case _PROC_EVENT_GID:
+		if len(body) < 16 {
+			return
+		}
+		event := &EventGid{
+			Tid:  binary.LittleEndian.Uint32(body[0:4]),
+			Pid:  binary.LittleEndian.Uint32(body[4:8]),
+			Rgid: binary.LittleEndian.Uint32(body[8:12]),
+			Egid: binary.LittleEndian.Uint32(body[12:16]),
+		}
+		trySend(pl.EventGid, event)
+		pl.dispatch(event)
+		pl.fanOut(Event{Kind: KindGid, Time: time.Now(), Gid: event})
+	case _PROC_EVENT_SID:
+		if len(body) < 8 {
+			return
+		}
+		event := &EventSid{
+			Tid: binary.LittleEndian.Uint32(body[0:4]),
+			Pid: binary.LittleEndian.Uint32(body[4:8]),
+		}
+		trySend(pl.EventSid, event)
+		pl.dispatch(event)
+		pl.fanOut(Event{Kind: KindSid, Time: time.Now(), Sid: event})
+	case _PROC_EVENT_EXIT:
+		if len(body) < 16 {
+			return
+		}
+		event := &EventExit{
+			Tid:    binary.LittleEndian.Uint32(body[0:4]),
+			Pid:    binary.LittleEndian.Uint32(body[4:8]),
+			Code:   binary.LittleEndian.Uint32(body[8:12]),
+			Signal: binary.LittleEndian.Uint32(body[12:16]),
+		}
+		trySend(pl.EventExit, event)
+		pl.dispatch(event)
+		pl.fanOut(Event{Kind: KindExit, Time: time.Now(), Exit: event})
+	}
+}
+
+// Close unsubscribes from the proc connector and closes the netlink socket.
+func (pl *ProcListener) Close() error {
+	_ = pl.setListen(_PROC_CN_MCAST_IGNORE)
+	return syscall.Close(pl.sock)
+}
+
+const (
+	nlMsgHdrLen = 16
+	cnMsgHdrLen = 20
+)
+
+func appendNlMsgHdr(buf []byte, payloadLen uint32) []byte {
+	buf = binary.LittleEndian.AppendUint32(buf, nlMsgHdrLen+payloadLen)
+	buf = binary.LittleEndian.AppendUint16(buf, syscall.NLMSG_DONE)
+	buf = binary.LittleEndian.AppendUint16(buf, 0)
+	buf = binary.LittleEndian.AppendUint32(buf, 0)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(os.Getpid()))
+	return buf
+}
+
+func appendCnMsgHdr(buf []byte, hdr cnMsgHeader) []byte {
+	buf = binary.LittleEndian.AppendUint32(buf, hdr.Idx)
+	buf = binary.LittleEndian.AppendUint32(buf, hdr.Val)
+	buf = binary.LittleEndian.AppendUint32(buf, hdr.Seq)
+	buf = binary.LittleEndian.AppendUint32(buf, hdr.Ack)
+	buf = binary.LittleEndian.AppendUint16(buf, hdr.Len)
+	buf = binary.LittleEndian.AppendUint16(buf, hdr.Flags)
+	return buf
+}
+
+func parseCnMsgHdr(data []byte) cnMsgHeader {
+	return cnMsgHeader{
+		Idx:   binary.LittleEndian.Uint32(data[0:4]),
+		Val:   binary.LittleEndian.Uint32(data[4:8]),
+		Seq:   binary.LittleEndian.Uint32(data[8:12]),
+		Ack:   binary.LittleEndian.Uint32(data[12:16]),
+		Len:   binary.LittleEndian.Uint16(data[16:18]),
+		Flags: binary.LittleEndian.Uint16(data[18:20]),
+	}
+}
@@ -0,0 +1,94 @@
+package pmon
+
+import (
+	"sync/atomic"
+	"syscall"
+)
+
+// EventDropped is delivered when ListenEvents detects a gap in the cn_msg
+// sequence numbers delivered by the kernel, meaning the connector dropped
+// one or more events (typically because the socket's receive buffer
+// overflowed under load).
+type EventDropped struct {
+	// FromSeq and ToSeq bound the gap: every sequence number in
+	// (FromSeq, ToSeq) was never delivered.
+	FromSeq, ToSeq uint32
+	// Count is the number of missing events, i.e. ToSeq-FromSeq-1.
+	Count uint32
+}
+
+// Stats reports cumulative delivery counters since Connect.
+type Stats struct {
+	// Received is the number of process events successfully decoded and
+	// delivered.
+	Received uint64
+	// Dropped is the number of events inferred lost from sequence gaps.
+	Dropped uint64
+	// Overflowed is the number of times Recvfrom reported ENOBUFS,
+	// meaning the kernel discarded one or more datagrams before pmon
+	// ever saw them.
+	Overflowed uint64
+}
+
+// Stats returns a snapshot of the listener's delivery counters.
+func (pl *ProcListener) Stats() Stats {
+	return Stats{
+		Received:   atomic.LoadUint64(&pl.received),
+		Dropped:    atomic.LoadUint64(&pl.dropped),
+		Overflowed: atomic.LoadUint64(&pl.overflowed),
+	}
+}
+
+// trackSeq records the cn_msg sequence number of a just-decoded event,
+// emitting EventDropped and incrementing the Dropped counter if it detects
+// a gap since the previous one.
+func (pl *ProcListener) trackSeq(seq uint32) {
+	atomic.AddUint64(&pl.received, 1)
+
+	pl.seqMu.Lock()
+	defer pl.seqMu.Unlock()
+
+	if !pl.haveSeq {
+		pl.haveSeq = true
+		pl.lastSeq = seq
+		return
+	}
+
+	if gap := seq - pl.lastSeq - 1; pl.lastSeq < seq && gap > 0 {
+		atomic.AddUint64(&pl.dropped, uint64(gap))
+		select {
+		case pl.EventDropped <- &EventDropped{FromSeq: pl.lastSeq, ToSeq: seq, Count: gap}:
+		default:
+		}
+	}
+
+	pl.lastSeq = seq
+}
+
+// SetRecvBuffer requests that the listener's netlink socket use a receive
+// buffer of at least bytes, so a fast-forking workload is less likely to
+// overflow it before ListenEvents can drain it. Call before Connect to
+// apply it at socket creation, or after Connect to resize the live socket.
+func (pl *ProcListener) SetRecvBuffer(bytes int) error {
+	pl.recvBuf = bytes
+	if pl.sock == 0 {
+		return nil
+	}
+	return pl.applyRecvBuffer(bytes)
+}
+
+// applyRecvBuffer sets SO_RCVBUF, falling back to SO_RCVBUFFORCE (which
+// requires CAP_NET_ADMIN, already needed to use this package at all) when
+// the kernel clamps SO_RCVBUF below the requested size.
+func (pl *ProcListener) applyRecvBuffer(bytes int) error {
+	if err := syscall.SetsockoptInt(pl.sock, syscall.SOL_SOCKET, syscall.SO_RCVBUF, bytes); err != nil {
+		return err
+	}
+
+	got, err := syscall.GetsockoptInt(pl.sock, syscall.SOL_SOCKET, syscall.SO_RCVBUF)
+	if err == nil && got >= bytes {
+		return nil
+	}
+
+	return syscall.SetsockoptInt(pl.sock, syscall.SOL_SOCKET, syscall.SO_RCVBUFFORCE, bytes)
+}
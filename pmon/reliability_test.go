@@ -0,0 +1,126 @@
+package pmon
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+)
+
+// TestStressForkDeliveryAccounting forks many short-lived children and
+// checks that Stats() reconciles: every cn_msg the kernel emits for the
+// run is either counted in Received or, if its sequence number shows a
+// gap, counted in Dropped. Stats() is a property of the connector socket
+// itself, not of any one consumer, so this says nothing about whether a
+// given Subscription actually observed every event -- per-consumer
+// channel drops (e.g. the best-effort trySend in dispatch) have no
+// counter anywhere in this package.
+func TestStressForkDeliveryAccounting(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping stress test in -short mode")
+	}
+
+	const childCount = 2000
+	const eventsPerChild = 3 // fork + exec + exit
+
+	pl := &ProcListener{}
+	if err := pl.Connect(); err != nil {
+		t.Fatal("Failed connect")
+	}
+	defer pl.Close()
+
+	go pl.ListenEvents()
+
+	done := make(chan bool, 1)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-pl.EventFork:
+			case <-pl.EventExec:
+			case <-pl.EventUid:
+			case <-pl.EventGid:
+			case <-pl.EventSid:
+			case <-pl.EventExit:
+			case <-pl.EventDropped:
+			case <-pl.Error:
+			}
+		}
+	}()
+
+	for i := 0; i < childCount; i++ {
+		cmd := exec.Command("true")
+		if err := cmd.Run(); err != nil {
+			t.Fatalf("Error running child %d: %v", i, err)
+		}
+	}
+
+	time.Sleep(500 * time.Millisecond)
+	done <- true
+
+	stats := pl.Stats()
+	if stats.Received == 0 {
+		t.Skip("no proc connector events observed; likely missing CAP_NET_ADMIN in this environment")
+	}
+
+	// Other processes on the system may add events of their own during
+	// the run, but never fewer than our children account for, so this
+	// is a lower bound rather than an exact match.
+	if accounted, want := stats.Received+stats.Dropped, uint64(childCount*eventsPerChild); accounted < want {
+		t.Errorf("Received (%d) + Dropped (%d) = %d, want at least %d for %d children",
+			stats.Received, stats.Dropped, accounted, want, childCount)
+	}
+}
+
+func TestTrackSeqDetectsGap(t *testing.T) {
+	pl := &ProcListener{EventDropped: make(chan *EventDropped, 1)}
+
+	pl.trackSeq(10)
+	pl.trackSeq(14)
+
+	select {
+	case dropped := <-pl.EventDropped:
+		if dropped.FromSeq != 10 || dropped.ToSeq != 14 || dropped.Count != 3 {
+			t.Errorf("Expected gap {10,14,3}, got %+v", dropped)
+		}
+	default:
+		t.Fatal("Expected an EventDropped for the gap between seq 10 and 14")
+	}
+
+	stats := pl.Stats()
+	if stats.Received != 2 {
+		t.Errorf("Expected Received 2, got %d", stats.Received)
+	}
+	if stats.Dropped != 3 {
+		t.Errorf("Expected Dropped 3, got %d", stats.Dropped)
+	}
+}
+
+func TestTrackSeqNoGapForConsecutive(t *testing.T) {
+	pl := &ProcListener{EventDropped: make(chan *EventDropped, 1)}
+
+	pl.trackSeq(1)
+	pl.trackSeq(2)
+	pl.trackSeq(3)
+
+	select {
+	case dropped := <-pl.EventDropped:
+		t.Errorf("Did not expect an EventDropped for consecutive sequence numbers, got %+v", dropped)
+	default:
+	}
+
+	if pl.Stats().Dropped != 0 {
+		t.Errorf("Expected Dropped 0, got %d", pl.Stats().Dropped)
+	}
+}
+
+func TestSetRecvBufferBeforeConnectIsDeferred(t *testing.T) {
+	pl := &ProcListener{}
+
+	if err := pl.SetRecvBuffer(1 << 20); err != nil {
+		t.Fatalf("SetRecvBuffer before Connect should not touch the socket: %v", err)
+	}
+	if pl.recvBuf != 1<<20 {
+		t.Errorf("Expected recvBuf to be recorded for Connect to apply, got %d", pl.recvBuf)
+	}
+}
@@ -0,0 +1,135 @@
+package pmon
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// EventKind identifies which process event type an Event wraps.
+type EventKind string
+
+const (
+	KindFork EventKind = "fork"
+	KindExec EventKind = "exec"
+	KindUid  EventKind = "uid"
+	KindGid  EventKind = "gid"
+	KindSid  EventKind = "sid"
+	KindExit EventKind = "exit"
+)
+
+// Event is the sink-facing representation of a process event: a kind tag,
+// the time pmon observed it, and exactly one of the typed payload fields
+// populated matching Kind.
+type Event struct {
+	Kind EventKind
+	Time time.Time
+
+	Fork *EventFork
+	Exec *EventExec
+	Uid  *EventUid
+	Gid  *EventGid
+	Sid  *EventSid
+	Exit *EventExit
+}
+
+// Sink receives a copy of every process event ProcListener observes. Write
+// is called from the sink's own delivery goroutine, never concurrently
+// with itself, so implementations don't need to be goroutine-safe with
+// respect to other Sink calls.
+type Sink interface {
+	Write(Event) error
+	Flush() error
+	Close() error
+}
+
+// sinkRegistration owns the buffered delivery goroutine for one registered
+// Sink.
+type sinkRegistration struct {
+	sink    Sink
+	events  chan Event
+	dropped uint64
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// RegisterSink starts fanning out events to sink on its own buffered
+// goroutine. bufferSize bounds how many events may queue before Write is
+// slow; once full, further events for this sink are dropped and counted
+// rather than blocking the dispatch loop.
+func (pl *ProcListener) RegisterSink(sink Sink, bufferSize int) {
+	if bufferSize <= 0 {
+		bufferSize = 256
+	}
+
+	reg := &sinkRegistration{
+		sink:    sink,
+		events:  make(chan Event, bufferSize),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+
+	pl.mu.Lock()
+	pl.sinks = append(pl.sinks, reg)
+	pl.mu.Unlock()
+
+	go reg.run()
+}
+
+func (reg *sinkRegistration) run() {
+	defer close(reg.stopped)
+
+	for {
+		select {
+		case <-reg.done:
+			return
+		case event := <-reg.events:
+			reg.sink.Write(event)
+		}
+	}
+}
+
+// SinkStats reports, per registered sink (in registration order), how many
+// events were dropped because its buffer was full.
+func (pl *ProcListener) SinkStats() []uint64 {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	stats := make([]uint64, len(pl.sinks))
+	for i, reg := range pl.sinks {
+		stats[i] = atomic.LoadUint64(&reg.dropped)
+	}
+	return stats
+}
+
+// CloseSinks flushes and closes every registered sink. It waits for each
+// sink's delivery goroutine to actually exit before calling Flush/Close, so
+// they never run concurrently with a pending Write.
+func (pl *ProcListener) CloseSinks() {
+	pl.mu.Lock()
+	sinks := append([]*sinkRegistration(nil), pl.sinks...)
+	pl.sinks = nil
+	pl.mu.Unlock()
+
+	for _, reg := range sinks {
+		close(reg.done)
+		<-reg.stopped
+		reg.sink.Flush()
+		reg.sink.Close()
+	}
+}
+
+// fanOut delivers event to every registered sink, dropping and counting
+// rather than blocking when a sink's buffer is full.
+func (pl *ProcListener) fanOut(event Event) {
+	pl.mu.Lock()
+	sinks := pl.sinks
+	pl.mu.Unlock()
+
+	for _, reg := range sinks {
+		select {
+		case reg.events <- event:
+		default:
+			atomic.AddUint64(&reg.dropped, 1)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Package jsonlsink implements a pmon.Sink that writes one JSON object per
+// line, with a stable schema across event kinds.
+package jsonlsink
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/vsurjaninov/procev/pmon"
+)
+
+// record is the stable on-disk schema: every field except Kind and Time is
+// omitted unless it applies to that event's kind.
+type record struct {
+	Kind string    `json:"kind"`
+	Time time.Time `json:"time"`
+
+	ParentPid *uint32 `json:"parent_pid,omitempty"`
+	ParentTid *uint32 `json:"parent_tid,omitempty"`
+	ChildPid  *uint32 `json:"child_pid,omitempty"`
+	ChildTid  *uint32 `json:"child_tid,omitempty"`
+
+	Pid  *uint32 `json:"pid,omitempty"`
+	Tid  *uint32 `json:"tid,omitempty"`
+	Ruid *uint32 `json:"ruid,omitempty"`
+	Euid *uint32 `json:"euid,omitempty"`
+	Rgid *uint32 `json:"rgid,omitempty"`
+	Egid *uint32 `json:"egid,omitempty"`
+
+	Code   *uint32 `json:"code,omitempty"`
+	Signal *uint32 `json:"signal,omitempty"`
+}
+
+// Sink writes events as JSON lines to w. Writes are buffered; call Flush or
+// Close to guarantee they reach w.
+type Sink struct {
+	w *bufio.Writer
+	c io.Closer
+}
+
+// New returns a Sink writing to w. If w also implements io.Closer, Close
+// closes it after flushing.
+func New(w io.Writer) *Sink {
+	s := &Sink{w: bufio.NewWriter(w)}
+	if c, ok := w.(io.Closer); ok {
+		s.c = c
+	}
+	return s
+}
+
+// Write appends one JSON line for event.
+func (s *Sink) Write(event pmon.Event) error {
+	rec := record{Kind: string(event.Kind), Time: event.Time}
+
+	switch event.Kind {
+	case pmon.KindFork:
+		rec.ParentPid, rec.ParentTid = &event.Fork.ParentPid, &event.Fork.ParentTid
+		rec.ChildPid, rec.ChildTid = &event.Fork.ChildPid, &event.Fork.ChildTid
+	case pmon.KindExec:
+		rec.Pid, rec.Tid = &event.Exec.Pid, &event.Exec.Tid
+	case pmon.KindUid:
+		rec.Pid, rec.Tid = &event.Uid.Pid, &event.Uid.Tid
+		rec.Ruid, rec.Euid = &event.Uid.Ruid, &event.Uid.Euid
+	case pmon.KindGid:
+		rec.Pid, rec.Tid = &event.Gid.Pid, &event.Gid.Tid
+		rec.Rgid, rec.Egid = &event.Gid.Rgid, &event.Gid.Egid
+	case pmon.KindSid:
+		rec.Pid, rec.Tid = &event.Sid.Pid, &event.Sid.Tid
+	case pmon.KindExit:
+		rec.Pid, rec.Tid = &event.Exit.Pid, &event.Exit.Tid
+		rec.Code, rec.Signal = &event.Exit.Code, &event.Exit.Signal
+	}
+
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	_, err = s.w.Write(line)
+	return err
+}
+
+// Flush writes any buffered lines to the underlying writer.
+func (s *Sink) Flush() error {
+	return s.w.Flush()
+}
+
+// Close flushes and, if the underlying writer is closeable, closes it.
+func (s *Sink) Close() error {
+	if err := s.w.Flush(); err != nil {
+		return err
+	}
+	if s.c != nil {
+		return s.c.Close()
+	}
+	return nil
+}
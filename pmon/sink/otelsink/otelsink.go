@@ -0,0 +1,78 @@
+// Package otelsink implements a pmon.Sink that emits OpenTelemetry log
+// records for process events.
+package otelsink
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/log"
+
+	"github.com/vsurjaninov/procev/pmon"
+)
+
+// Sink emits one log.Record per process event via an OpenTelemetry
+// log.Logger.
+type Sink struct {
+	logger log.Logger
+}
+
+// New returns a Sink emitting records through logger, e.g. one obtained
+// from a log.LoggerProvider via Logger("procev").
+func New(logger log.Logger) *Sink {
+	return &Sink{logger: logger}
+}
+
+// Write emits a log record with severity and attributes derived from
+// event.
+func (s *Sink) Write(event pmon.Event) error {
+	var rec log.Record
+	rec.SetTimestamp(event.Time)
+	rec.SetSeverity(log.SeverityInfo)
+	rec.SetBody(log.StringValue(fmt.Sprintf("procev %s", event.Kind)))
+
+	attrs := []log.KeyValue{log.String("procev.kind", string(event.Kind))}
+
+	switch event.Kind {
+	case pmon.KindFork:
+		attrs = append(attrs,
+			log.Int64("procev.parent_pid", int64(event.Fork.ParentPid)),
+			log.Int64("procev.child_pid", int64(event.Fork.ChildPid)),
+		)
+	case pmon.KindExec:
+		attrs = append(attrs, log.Int64("procev.pid", int64(event.Exec.Pid)))
+	case pmon.KindUid:
+		attrs = append(attrs,
+			log.Int64("procev.pid", int64(event.Uid.Pid)),
+			log.Int64("procev.ruid", int64(event.Uid.Ruid)),
+			log.Int64("procev.euid", int64(event.Uid.Euid)),
+		)
+	case pmon.KindGid:
+		attrs = append(attrs,
+			log.Int64("procev.pid", int64(event.Gid.Pid)),
+			log.Int64("procev.rgid", int64(event.Gid.Rgid)),
+			log.Int64("procev.egid", int64(event.Gid.Egid)),
+		)
+	case pmon.KindSid:
+		attrs = append(attrs, log.Int64("procev.pid", int64(event.Sid.Pid)))
+	case pmon.KindExit:
+		attrs = append(attrs,
+			log.Int64("procev.pid", int64(event.Exit.Pid)),
+			log.Int64("procev.code", int64(event.Exit.Code)),
+			log.Int64("procev.signal", int64(event.Exit.Signal)),
+		)
+	}
+
+	rec.AddAttributes(attrs...)
+
+	s.logger.Emit(context.Background(), rec)
+	return nil
+}
+
+// Flush is a no-op: delivery buffering belongs to the configured
+// log.LoggerProvider's exporter, not this sink.
+func (s *Sink) Flush() error { return nil }
+
+// Close is a no-op for the same reason as Flush; shut down the
+// log.LoggerProvider separately.
+func (s *Sink) Close() error { return nil }
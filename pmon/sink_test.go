@@ -0,0 +1,80 @@
+package pmon
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingSink struct {
+	mu     sync.Mutex
+	events []Event
+	closed bool
+}
+
+func (s *recordingSink) Write(event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *recordingSink) Flush() error { return nil }
+
+func (s *recordingSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+func (s *recordingSink) snapshot() []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Event(nil), s.events...)
+}
+
+func TestSinkReceivesForkEvents(t *testing.T) {
+	tl := newTestListener(t)
+	sink := &recordingSink{}
+	tl.listener.RegisterSink(sink, 16)
+
+	childPid := forkChild(t, 1000, 65534)
+	tl.close()
+	tl.listener.CloseSinks()
+
+	found := false
+	for _, event := range sink.snapshot() {
+		if event.Kind == KindFork && event.Fork.ChildPid == uint32(childPid) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected fork event for pid %d in sink", childPid)
+	}
+	if !sink.closed {
+		t.Errorf("Expected CloseSinks to close the registered sink")
+	}
+}
+
+func TestSinkDropsWhenBufferFull(t *testing.T) {
+	tl := newTestListener(t)
+	sink := &recordingSink{}
+	tl.listener.RegisterSink(sink, 1)
+
+	// Fork repeatedly faster than a buffer of 1 can be drained reliably;
+	// this should produce dropped events without blocking the listener.
+	for i := 0; i < 20; i++ {
+		forkChild(t, 1000, 65534)
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	tl.close()
+
+	stats := tl.listener.SinkStats()
+	if len(stats) != 1 {
+		t.Fatalf("Expected 1 sink stat entry, got %d", len(stats))
+	}
+
+	tl.listener.CloseSinks()
+}